@@ -0,0 +1,95 @@
+package heap
+
+// RejectionPolicy controls what Push does when called on a full bounded
+// PriorityQueue (one created with NewWithCapacity or NewBounded).
+type RejectionPolicy int
+
+const (
+	// DropWorst evicts the current lowest-priority element to make room
+	// for the incoming one, but only if the incoming one is better; this
+	// is the zero value and the default for NewWithCapacity.
+	DropWorst RejectionPolicy = iota
+	// DropNewest silently leaves the queue unchanged and reports the
+	// incoming element as not added.
+	DropNewest
+	// Reject behaves like DropNewest but also invokes OnEvict with the
+	// rejected incoming element, so callers building backpressured
+	// pipelines can observe what was dropped.
+	Reject
+	// Block waits, via ConcurrentPriorityQueue.Push, for Pop to free a
+	// slot instead of dropping anything. A plain PriorityQueue has no
+	// goroutine that could ever free a slot for it, so there Push treats
+	// Block like DropNewest instead of blocking forever.
+	Block
+)
+
+// NewBounded creates a bounded PriorityQueue with an explicit
+// RejectionPolicy and an optional onEvict callback invoked with the element
+// DropWorst evicts or Reject turns away; DropNewest and Block drop the
+// incoming element without calling it. Pass a nil onEvict to disable it.
+func NewBounded[T any](comparator Comparator[T], capacity int, policy RejectionPolicy, onEvict func(T)) *PriorityQueue[T] {
+	if capacity <= 0 {
+		panic("heap: capacity must be positive")
+	}
+	return &PriorityQueue[T]{
+		data:       make([]T, 0, capacity),
+		comparator: comparator,
+		capacity:   capacity,
+		policy:     policy,
+		onEvict:    onEvict,
+	}
+}
+
+// SetRejectionPolicy configures how a bounded queue behaves once Push is
+// called on a full queue. It has no effect on unbounded queues.
+func (pq *PriorityQueue[T]) SetRejectionPolicy(policy RejectionPolicy) {
+	pq.policy = policy
+}
+
+// SetOnEvict registers a callback invoked with the element a full bounded
+// queue's Push evicts (DropWorst) or turns away (Reject). Pass nil to
+// disable it.
+func (pq *PriorityQueue[T]) SetOnEvict(onEvict func(T)) {
+	pq.onEvict = onEvict
+}
+
+// pushFull applies pq's RejectionPolicy when Push is called on a full
+// bounded queue.
+func (pq *PriorityQueue[T]) pushFull(x T) bool {
+	switch pq.policy {
+	case DropNewest, Block:
+		return false
+	case Reject:
+		if pq.onEvict != nil {
+			pq.onEvict(x)
+		}
+		return false
+	default: // DropWorst
+		worst := pq.worstIndex()
+		if pq.comparator(x, pq.data[worst]) >= 0 {
+			return false // x is not better than the current worst element
+		}
+		evicted := pq.data[worst]
+		pq.removeAt(worst)
+		if pq.onEvict != nil {
+			pq.onEvict(evicted)
+		}
+		pq.data = append(pq.data, x)
+		pq.bubbleUp(len(pq.data) - 1)
+		return true
+	}
+}
+
+// worstIndex returns the index of the lowest-priority element, i.e. the
+// element Pop would return last. Only leaves (the back half of the slice)
+// can be the worst element in a min-heap, so this is O(n/2) rather than a
+// full O(n) scan.
+func (pq *PriorityQueue[T]) worstIndex() int {
+	worst := len(pq.data) - 1
+	for i := len(pq.data) / 2; i < len(pq.data); i++ {
+		if pq.comparator(pq.data[i], pq.data[worst]) > 0 {
+			worst = i
+		}
+	}
+	return worst
+}
@@ -0,0 +1,94 @@
+package heap
+
+import (
+	"reflect"
+	"testing"
+)
+
+// byValueDesc treats larger ints as higher priority, so a DropWorst bounded
+// queue built with it keeps the largest values seen — the classic top-K
+// selection use case for a bounded heap.
+func byValueDesc(a, b int) int { return b - a }
+
+func TestBoundedPushDropWorstTopK(t *testing.T) {
+	bq := NewWithCapacity(byValueDesc, 3)
+	for _, v := range []int{5, 1, 9, 3, 7, 2, 8, 4, 6, 0} {
+		bq.Push(v)
+	}
+
+	got := bq.Sorted()
+	want := []int{9, 8, 7}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Sorted() = %v, want %v (top-3 of the stream)", got, want)
+	}
+}
+
+func TestBoundedPushDropWorstRejectsWorseElement(t *testing.T) {
+	bq := NewWithCapacity(byValueDesc, 3)
+	for _, v := range []int{10, 20, 30} {
+		bq.Push(v)
+	}
+
+	if bq.Push(1) {
+		t.Fatal("Push(1) should be rejected: 1 is worse than the current worst (10)")
+	}
+	if !bq.Push(100) {
+		t.Fatal("Push(100) should be accepted: 100 is better than the current worst (10)")
+	}
+
+	got := bq.Sorted()
+	want := []int{100, 30, 20}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Sorted() = %v, want %v", got, want)
+	}
+}
+
+func TestBoundedPushDropNewest(t *testing.T) {
+	bq := NewBounded(byValueDesc, 3, DropNewest, nil)
+	for _, v := range []int{10, 20, 30} {
+		bq.Push(v)
+	}
+
+	if bq.Push(100) {
+		t.Fatal("Push should report the incoming element as not added under DropNewest")
+	}
+
+	got := bq.Sorted()
+	want := []int{30, 20, 10}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Sorted() = %v, want %v (queue left unchanged)", got, want)
+	}
+}
+
+func TestBoundedPushReject(t *testing.T) {
+	var evicted []int
+	bq := NewBounded(byValueDesc, 3, Reject, func(x int) { evicted = append(evicted, x) })
+	for _, v := range []int{10, 20, 30} {
+		bq.Push(v)
+	}
+
+	if bq.Push(1) {
+		t.Fatal("Push should report the incoming element as not added under Reject")
+	}
+
+	want := []int{1}
+	if !reflect.DeepEqual(evicted, want) {
+		t.Fatalf("OnEvict saw %v, want %v (the rejected incoming element)", evicted, want)
+	}
+}
+
+func TestBoundedPushDropWorstOnEvict(t *testing.T) {
+	var evicted []int
+	bq := NewBounded(byValueDesc, 3, DropWorst, func(x int) { evicted = append(evicted, x) })
+	for _, v := range []int{10, 20, 30} {
+		bq.Push(v)
+	}
+
+	bq.Push(100)
+	bq.Push(5) // worse than every remaining element, should be rejected without eviction
+
+	want := []int{10}
+	if !reflect.DeepEqual(evicted, want) {
+		t.Fatalf("OnEvict saw %v, want %v (only the evicted worst element)", evicted, want)
+	}
+}
@@ -0,0 +1,133 @@
+package heap
+
+import (
+	"context"
+	"sync"
+)
+
+// ConcurrentPriorityQueue wraps a PriorityQueue with a mutex so it can be
+// shared safely across goroutines. The same Push/Pop/Peek/Len API is
+// exposed, plus a blocking PopWait for consumers that want to wait for
+// work. The underlying PriorityQueue is untouched, so single-threaded
+// users pay for locking only when they opt into this wrapper.
+type ConcurrentPriorityQueue[T any] struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	pq   *PriorityQueue[T]
+}
+
+// NewConcurrent wraps pq in a ConcurrentPriorityQueue.
+func NewConcurrent[T any](pq *PriorityQueue[T]) *ConcurrentPriorityQueue[T] {
+	cpq := &ConcurrentPriorityQueue[T]{pq: pq}
+	cpq.cond = sync.NewCond(&cpq.mu)
+	return cpq
+}
+
+// Push inserts x into the queue and wakes any goroutine blocked in
+// PopWait. If the wrapped queue is bounded, full, and configured with the
+// Block RejectionPolicy, Push waits for a Pop to free a slot instead of
+// applying a drop policy.
+func (cpq *ConcurrentPriorityQueue[T]) Push(x T) bool {
+	cpq.mu.Lock()
+	defer cpq.mu.Unlock()
+	for cpq.pq.policy == Block && cpq.pq.capacity > 0 && len(cpq.pq.data) >= cpq.pq.capacity {
+		cpq.cond.Wait()
+	}
+	ok := cpq.pq.Push(x)
+	if ok {
+		cpq.cond.Broadcast()
+	}
+	return ok
+}
+
+// PushMany inserts each of xs, waking blocked PopWait callers once if any
+// insertion succeeded. It returns the number of elements actually pushed.
+func (cpq *ConcurrentPriorityQueue[T]) PushMany(xs []T) int {
+	cpq.mu.Lock()
+	defer cpq.mu.Unlock()
+	n := 0
+	for _, x := range xs {
+		if cpq.pq.Push(x) {
+			n++
+		}
+	}
+	if n > 0 {
+		cpq.cond.Broadcast()
+	}
+	return n
+}
+
+// Pop removes and returns the highest priority element, if any, without
+// blocking. It wakes any goroutine blocked in Push waiting for a slot to
+// free up on a bounded, Block-policy queue.
+func (cpq *ConcurrentPriorityQueue[T]) Pop() (T, bool) {
+	cpq.mu.Lock()
+	defer cpq.mu.Unlock()
+	x, ok := cpq.pq.Pop()
+	if ok {
+		cpq.cond.Broadcast()
+	}
+	return x, ok
+}
+
+// PopWait blocks until an element is available or ctx is cancelled, in
+// which case it returns ctx.Err().
+func (cpq *ConcurrentPriorityQueue[T]) PopWait(ctx context.Context) (T, error) {
+	cpq.mu.Lock()
+	defer cpq.mu.Unlock()
+
+	if ctx.Done() != nil {
+		stop := context.AfterFunc(ctx, func() {
+			cpq.mu.Lock()
+			cpq.cond.Broadcast()
+			cpq.mu.Unlock()
+		})
+		defer stop()
+	}
+
+	for cpq.pq.Len() == 0 {
+		if err := ctx.Err(); err != nil {
+			var zero T
+			return zero, err
+		}
+		cpq.cond.Wait()
+	}
+	x, _ := cpq.pq.Pop()
+	cpq.cond.Broadcast()
+	return x, nil
+}
+
+// Peek returns the highest priority element without removing it.
+func (cpq *ConcurrentPriorityQueue[T]) Peek() (T, bool) {
+	cpq.mu.Lock()
+	defer cpq.mu.Unlock()
+	return cpq.pq.Peek()
+}
+
+// Len returns the number of elements in the queue.
+func (cpq *ConcurrentPriorityQueue[T]) Len() int {
+	cpq.mu.Lock()
+	defer cpq.mu.Unlock()
+	return cpq.pq.Len()
+}
+
+// DrainTo pops every element currently in the queue, in priority order,
+// and appends them to dst, returning the extended slice. It wakes any
+// goroutine blocked in Push waiting for a slot to free up.
+func (cpq *ConcurrentPriorityQueue[T]) DrainTo(dst []T) []T {
+	cpq.mu.Lock()
+	defer cpq.mu.Unlock()
+	drained := false
+	for {
+		x, ok := cpq.pq.Pop()
+		if !ok {
+			break
+		}
+		dst = append(dst, x)
+		drained = true
+	}
+	if drained {
+		cpq.cond.Broadcast()
+	}
+	return dst
+}
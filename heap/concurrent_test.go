@@ -0,0 +1,155 @@
+package heap
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentPriorityQueuePopWaitBlocksUntilPush(t *testing.T) {
+	cpq := NewConcurrent(New(func(a, b int) int { return a - b }))
+
+	type result struct {
+		v   int
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := cpq.PopWait(context.Background())
+		done <- result{v, err}
+	}()
+
+	select {
+	case r := <-done:
+		t.Fatalf("PopWait returned early with (%d, %v) before anything was pushed", r.v, r.err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cpq.Push(42)
+
+	select {
+	case r := <-done:
+		if r.err != nil || r.v != 42 {
+			t.Fatalf("PopWait() = (%d, %v), want (42, nil)", r.v, r.err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("PopWait never returned after Push")
+	}
+}
+
+func TestConcurrentPriorityQueuePopWaitCancellation(t *testing.T) {
+	cpq := NewConcurrent(New(func(a, b int) int { return a - b }))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := cpq.PopWait(ctx)
+		done <- err
+	}()
+
+	// Give PopWait a moment to actually start waiting before cancelling.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Fatalf("PopWait() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("PopWait did not return after context cancellation")
+	}
+}
+
+func TestConcurrentPriorityQueueBlockPolicyBackpressure(t *testing.T) {
+	bounded := NewBounded(func(a, b int) int { return a - b }, 1, Block, nil)
+	cpq := NewConcurrent(bounded)
+
+	if !cpq.Push(1) {
+		t.Fatal("first Push into an empty bounded queue should succeed")
+	}
+
+	pushed := make(chan bool, 1)
+	go func() {
+		pushed <- cpq.Push(2)
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatal("Push should block while the bounded queue is full under the Block policy")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	v, ok := cpq.Pop()
+	if !ok || v != 1 {
+		t.Fatalf("Pop() = (%d, %v), want (1, true)", v, ok)
+	}
+
+	select {
+	case ok := <-pushed:
+		if !ok {
+			t.Fatal("blocked Push should have succeeded once Pop freed a slot")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked Push never unblocked after Pop freed a slot")
+	}
+
+	if cpq.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1", cpq.Len())
+	}
+}
+
+func TestConcurrentPriorityQueuePushManyAndDrainTo(t *testing.T) {
+	cpq := NewConcurrent(New(func(a, b int) int { return a - b }))
+
+	n := cpq.PushMany([]int{5, 3, 9, 1, 7})
+	if n != 5 {
+		t.Fatalf("PushMany returned %d, want 5", n)
+	}
+
+	got := cpq.DrainTo(nil)
+	want := []int{1, 3, 5, 7, 9}
+	if len(got) != len(want) {
+		t.Fatalf("DrainTo() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("DrainTo() = %v, want %v", got, want)
+		}
+	}
+	if cpq.Len() != 0 {
+		t.Fatalf("Len() = %d after DrainTo, want 0", cpq.Len())
+	}
+}
+
+func TestConcurrentPriorityQueueConcurrentPushPop(t *testing.T) {
+	cpq := NewConcurrent(New(func(a, b int) int { return a - b }))
+
+	var wg sync.WaitGroup
+	const n = 100
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(v int) {
+			defer wg.Done()
+			cpq.Push(v)
+		}(i)
+	}
+	wg.Wait()
+
+	if cpq.Len() != n {
+		t.Fatalf("Len() = %d, want %d", cpq.Len(), n)
+	}
+
+	seen := make(map[int]bool)
+	for i := 0; i < n; i++ {
+		v, ok := cpq.Pop()
+		if !ok {
+			t.Fatalf("Pop() returned false with %d elements left", n-i)
+		}
+		if seen[v] {
+			t.Fatalf("Pop() returned duplicate value %d", v)
+		}
+		seen[v] = true
+	}
+}
@@ -0,0 +1,80 @@
+package heap
+
+// From creates a new PriorityQueue from items using heapify, which runs in
+// O(n) instead of the O(n log n) required by pushing items one at a time.
+// From takes ownership of items; callers should not use the slice
+// afterward.
+func From[T any](comparator Comparator[T], items []T) *PriorityQueue[T] {
+	pq := &PriorityQueue[T]{
+		data:       items,
+		comparator: comparator,
+	}
+	for i := len(pq.data)/2 - 1; i >= 0; i-- {
+		pq.bubbleDown(i)
+	}
+	return pq
+}
+
+// FromOrdered is like From but for Ordered types, using the same natural
+// min-heap ordering as NewOrdered.
+func FromOrdered[T Ordered](items []T) *PriorityQueue[T] {
+	return From(func(a, b T) int {
+		if a < b {
+			return -1
+		}
+		if a > b {
+			return 1
+		}
+		return 0
+	}, items)
+}
+
+// Merge concatenates the backing slices of pqs and re-heapifies the result
+// in O(N), using the first queue's comparator. pqs are drained of their
+// backing slices and should not be used afterward.
+func Merge[T any](pqs ...*PriorityQueue[T]) *PriorityQueue[T] {
+	if len(pqs) == 0 {
+		panic("heap: Merge requires at least one queue")
+	}
+	total := 0
+	for _, pq := range pqs {
+		total += len(pq.data)
+	}
+	merged := make([]T, 0, total)
+	for _, pq := range pqs {
+		merged = append(merged, pq.data...)
+		pq.data = nil
+	}
+	return From(pqs[0].comparator, merged)
+}
+
+// MergeSorted performs a k-way merge of iters, each of which must yield
+// values already in comparator order, using an internal PriorityQueue (a
+// binary heap) of iterator heads. It returns a function that
+// yields the next value in merged order and a bool reporting whether one
+// was available.
+func MergeSorted[T any](comparator Comparator[T], iters ...func() (T, bool)) func() (T, bool) {
+	type head struct {
+		value T
+		next  func() (T, bool)
+	}
+	pq := New(func(a, b head) int {
+		return comparator(a.value, b.value)
+	})
+	for _, it := range iters {
+		if v, ok := it(); ok {
+			pq.Push(head{value: v, next: it})
+		}
+	}
+	return func() (T, bool) {
+		h, ok := pq.Pop()
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		if v, ok := h.next(); ok {
+			pq.Push(head{value: v, next: h.next})
+		}
+		return h.value, true
+	}
+}
@@ -0,0 +1,92 @@
+package heap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFromHeapifiesAndPopsInOrder(t *testing.T) {
+	items := []int{5, 1, 9, 3, 7, 2, 8, 4, 6, 0}
+	pq := From(func(a, b int) int { return a - b }, items)
+
+	if pq.Len() != 10 {
+		t.Fatalf("Len() = %d, want 10", pq.Len())
+	}
+	got := pq.PopAll()
+	want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("PopAll() = %v, want %v", got, want)
+	}
+}
+
+func TestFromOrdered(t *testing.T) {
+	pq := FromOrdered([]int{3, 1, 2})
+	got := pq.PopAll()
+	want := []int{1, 2, 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("PopAll() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeConcatenatesAndReheapifies(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+	a := From(cmp, []int{5, 1, 9})
+	b := From(cmp, []int{3, 7, 2})
+	c := From(cmp, []int{8, 4, 6})
+
+	merged := Merge(a, b, c)
+	if merged.Len() != 9 {
+		t.Fatalf("Len() = %d, want 9", merged.Len())
+	}
+	got := merged.PopAll()
+	want := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("PopAll() = %v, want %v", got, want)
+	}
+}
+
+func TestMergePanicsOnNoQueues(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Merge() with no queues should panic")
+		}
+	}()
+	Merge[int]()
+}
+
+func TestMergeSortedKWayMerge(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+
+	sliceIter := func(s []int) func() (int, bool) {
+		i := 0
+		return func() (int, bool) {
+			if i >= len(s) {
+				return 0, false
+			}
+			v := s[i]
+			i++
+			return v, true
+		}
+	}
+
+	next := MergeSorted(cmp,
+		sliceIter([]int{1, 4, 7}),
+		sliceIter([]int{2, 5, 8}),
+		sliceIter([]int{3, 6, 9}),
+		sliceIter(nil),
+	)
+
+	var got []int
+	for {
+		v, ok := next()
+		if !ok {
+			break
+		}
+		got = append(got, v)
+	}
+
+	want := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("MergeSorted output = %v, want %v", got, want)
+	}
+}
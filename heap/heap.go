@@ -11,7 +11,9 @@ type Comparator[T any] func(a, b T) int
 type PriorityQueue[T any] struct {
 	data       []T
 	comparator Comparator[T]
-	capacity   int // maximum capacity; if <= 0, the queue is unbounded
+	capacity   int             // maximum capacity; if <= 0, the queue is unbounded
+	policy     RejectionPolicy // what Push does once a bounded queue is full
+	onEvict    func(T)         // invoked with the element dropped or evicted by Push, if set
 }
 
 // New creates a new PriorityQueue with the given comparator.
@@ -76,14 +78,12 @@ type Ordered interface {
 }
 
 // Push inserts an element into the queue.
-// If the queue is bounded and full, it removes the lowest priority element first.
+// If the queue is bounded and full, it applies the queue's RejectionPolicy
+// (DropWorst by default, which evicts the lowest priority element to make
+// room for a higher priority one). It returns false if x was not added.
 func (pq *PriorityQueue[T]) Push(x T) bool {
 	if pq.capacity > 0 && len(pq.data) >= pq.capacity {
-		// For bounded queue, check if new element has higher priority than current min
-		if pq.comparator(x, pq.data[0]) <= 0 {
-			return false // New element has lower priority, don't add it
-		}
-		pq.Pop() // Remove lowest priority element to make space
+		return pq.pushFull(x)
 	}
 	pq.data = append(pq.data, x)
 	pq.bubbleUp(len(pq.data) - 1)
@@ -0,0 +1,166 @@
+package heap
+
+// Handle is an opaque reference to an element stored in an
+// IndexedPriorityQueue. It stays valid across heap mutations (Push/Pop of
+// other elements) and lets a caller Update or RemoveHandle the element it
+// points to in O(log n) instead of the O(n) scan required by
+// PriorityQueue's Contains/Remove.
+type Handle[T any] struct {
+	value T
+	index int
+}
+
+// Value returns the element currently associated with h.
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// IndexedPriorityQueue is a generic heap that tracks each element's slice
+// position, updated on every swap, so a previously pushed element can be
+// updated or removed in O(log n) once its priority changes after insertion.
+type IndexedPriorityQueue[T any] struct {
+	data       []*Handle[T]
+	comparator Comparator[T]
+}
+
+// NewIndexed creates a new IndexedPriorityQueue with the given comparator.
+func NewIndexed[T any](comparator Comparator[T]) *IndexedPriorityQueue[T] {
+	return &IndexedPriorityQueue[T]{
+		data:       make([]*Handle[T], 0),
+		comparator: comparator,
+	}
+}
+
+// Push inserts value into the queue and returns a handle that can later be
+// passed to Update, Fix, or RemoveHandle.
+func (pq *IndexedPriorityQueue[T]) Push(value T) *Handle[T] {
+	h := &Handle[T]{value: value, index: len(pq.data)}
+	pq.data = append(pq.data, h)
+	pq.bubbleUp(h.index)
+	return h
+}
+
+// Pop removes and returns the highest priority element from the queue.
+func (pq *IndexedPriorityQueue[T]) Pop() (T, bool) {
+	if len(pq.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	top := pq.data[0]
+	n := len(pq.data) - 1
+	pq.swap(0, n)
+	pq.data[n].index = -1
+	pq.data = pq.data[:n]
+	if n > 0 {
+		pq.bubbleDown(0)
+	}
+	return top.value, true
+}
+
+// Peek returns the highest priority element without removing it.
+func (pq *IndexedPriorityQueue[T]) Peek() (T, bool) {
+	if len(pq.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.data[0].value, true
+}
+
+// Len returns the number of elements in the queue.
+func (pq *IndexedPriorityQueue[T]) Len() int {
+	return len(pq.data)
+}
+
+// IsEmpty returns true if the queue is empty.
+func (pq *IndexedPriorityQueue[T]) IsEmpty() bool {
+	return len(pq.data) == 0
+}
+
+// Update sets h's value to newValue and re-sifts it to restore the heap
+// property in O(log n). If h is not currently in the queue, only h's stored
+// value changes and the queue is left untouched.
+func (pq *IndexedPriorityQueue[T]) Update(h *Handle[T], newValue T) {
+	h.value = newValue
+	pq.Fix(h)
+}
+
+// Fix re-sifts h after its value has changed externally, restoring the
+// heap property in O(log n). It is a no-op if h is not currently in the
+// queue.
+func (pq *IndexedPriorityQueue[T]) Fix(h *Handle[T]) {
+	if h.index < 0 || h.index >= len(pq.data) || pq.data[h.index] != h {
+		return
+	}
+	if !pq.bubbleDown(h.index) {
+		pq.bubbleUp(h.index)
+	}
+}
+
+// RemoveHandle removes h from the queue in O(log n). Returns false if h is
+// not currently in the queue.
+func (pq *IndexedPriorityQueue[T]) RemoveHandle(h *Handle[T]) bool {
+	if h.index < 0 || h.index >= len(pq.data) || pq.data[h.index] != h {
+		return false
+	}
+	i := h.index
+	n := len(pq.data) - 1
+	pq.swap(i, n)
+	pq.data[n].index = -1
+	pq.data = pq.data[:n]
+	if i < n {
+		if !pq.bubbleDown(i) {
+			pq.bubbleUp(i)
+		}
+	}
+	return true
+}
+
+// swap exchanges the elements at i and j and keeps their indices in sync.
+func (pq *IndexedPriorityQueue[T]) swap(i, j int) {
+	pq.data[i], pq.data[j] = pq.data[j], pq.data[i]
+	pq.setIndex(pq.data[i], i)
+	pq.setIndex(pq.data[j], j)
+}
+
+// setIndex records h's current slice position.
+func (pq *IndexedPriorityQueue[T]) setIndex(h *Handle[T], i int) {
+	h.index = i
+}
+
+// bubbleUp restores the heap property from index i upward.
+func (pq *IndexedPriorityQueue[T]) bubbleUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if pq.comparator(pq.data[i].value, pq.data[parent].value) >= 0 {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// bubbleDown restores the heap property from index i downward.
+// Returns true if any swaps occurred.
+func (pq *IndexedPriorityQueue[T]) bubbleDown(i int) bool {
+	n := len(pq.data)
+	swapped := false
+	for {
+		smallest := i
+		left := 2*i + 1
+		right := 2*i + 2
+
+		if left < n && pq.comparator(pq.data[left].value, pq.data[smallest].value) < 0 {
+			smallest = left
+		}
+		if right < n && pq.comparator(pq.data[right].value, pq.data[smallest].value) < 0 {
+			smallest = right
+		}
+		if smallest == i {
+			break
+		}
+		pq.swap(i, smallest)
+		i = smallest
+		swapped = true
+	}
+	return swapped
+}
@@ -0,0 +1,162 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// bruteForcePQ is a deliberately naive reference model: a slice kept sorted
+// by re-sorting after every mutation. IndexedPriorityQueue's O(log n) ops
+// are checked against it after every step.
+type bruteForcePQ struct {
+	items []int
+}
+
+func (b *bruteForcePQ) push(x int) {
+	b.items = append(b.items, x)
+	sort.Ints(b.items)
+}
+
+func (b *bruteForcePQ) remove(x int) {
+	for i, v := range b.items {
+		if v == x {
+			b.items = append(b.items[:i], b.items[i+1:]...)
+			return
+		}
+	}
+}
+
+func (b *bruteForcePQ) update(old, newVal int) {
+	b.remove(old)
+	b.push(newVal)
+}
+
+func (b *bruteForcePQ) min() (int, bool) {
+	if len(b.items) == 0 {
+		return 0, false
+	}
+	return b.items[0], true
+}
+
+func (b *bruteForcePQ) pop() (int, bool) {
+	x, ok := b.min()
+	if ok {
+		b.remove(x)
+	}
+	return x, ok
+}
+
+func TestIndexedPriorityQueueRandomized(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+	rng := rand.New(rand.NewSource(1))
+
+	for trial := 0; trial < 200; trial++ {
+		pq := NewIndexed(cmp)
+		model := &bruteForcePQ{}
+		handles := make([]*Handle[int], 0)
+
+		for step := 0; step < 100; step++ {
+			// Drop handles Pop already invalidated so op 2/3 never target
+			// a stale one (the model has no way to know which handle a
+			// Pop corresponded to).
+			live := handles[:0]
+			for _, h := range handles {
+				if h.index >= 0 {
+					live = append(live, h)
+				}
+			}
+			handles = live
+
+			switch op := rng.Intn(4); {
+			case op == 0 || len(handles) == 0: // Push
+				v := rng.Intn(1000)
+				h := pq.Push(v)
+				handles = append(handles, h)
+				model.push(v)
+
+			case op == 1: // Pop
+				want, wantOk := model.pop()
+				got, gotOk := pq.Pop()
+				if gotOk != wantOk || got != want {
+					t.Fatalf("trial %d step %d: Pop() = (%d, %v), want (%d, %v)", trial, step, got, gotOk, want, wantOk)
+				}
+
+			case op == 2: // Update a random still-live handle
+				i := rng.Intn(len(handles))
+				h := handles[i]
+				old := h.Value()
+				newVal := rng.Intn(1000)
+				pq.Update(h, newVal)
+				model.update(old, newVal)
+
+			case op == 3: // RemoveHandle a random still-live handle
+				i := rng.Intn(len(handles))
+				h := handles[i]
+				old := h.Value()
+				if pq.RemoveHandle(h) {
+					model.remove(old)
+				}
+				handles = append(handles[:i], handles[i+1:]...)
+			}
+
+			if pq.Len() != len(model.items) {
+				t.Fatalf("trial %d step %d: Len() = %d, want %d", trial, step, pq.Len(), len(model.items))
+			}
+			wantPeek, wantOk := model.min()
+			gotPeek, gotOk := pq.Peek()
+			if gotOk != wantOk || (wantOk && gotPeek != wantPeek) {
+				t.Fatalf("trial %d step %d: Peek() = (%d, %v), want (%d, %v)", trial, step, gotPeek, gotOk, wantPeek, wantOk)
+			}
+		}
+
+		// Drain whatever remains and check it comes out in sorted order.
+		for {
+			want, wantOk := model.pop()
+			got, gotOk := pq.Pop()
+			if gotOk != wantOk || got != want {
+				t.Fatalf("trial %d drain: Pop() = (%d, %v), want (%d, %v)", trial, got, gotOk, want, wantOk)
+			}
+			if !wantOk {
+				break
+			}
+		}
+	}
+}
+
+func TestIndexedPriorityQueueFixAfterExternalMutation(t *testing.T) {
+	type item struct {
+		id       int
+		priority int
+	}
+	items := []*item{{1, 5}, {2, 3}, {3, 8}}
+	pq := NewIndexed(func(a, b *item) int { return a.priority - b.priority })
+
+	handles := make(map[int]*Handle[*item])
+	for _, it := range items {
+		handles[it.id] = pq.Push(it)
+	}
+
+	// Mutate item 3's priority externally, then Fix instead of Update.
+	items[2].priority = 0
+	pq.Fix(handles[3])
+
+	got, ok := pq.Peek()
+	if !ok || got.id != 3 {
+		t.Fatalf("Peek() = %v, want item 3 after its priority dropped to 0", got)
+	}
+}
+
+func TestIndexedPriorityQueueRemoveHandleStaleNoOp(t *testing.T) {
+	pq := NewIndexed(func(a, b int) int { return a - b })
+	h := pq.Push(5)
+	pq.Pop()
+
+	if pq.RemoveHandle(h) {
+		t.Fatal("RemoveHandle should return false for a handle that was already popped")
+	}
+	pq.Fix(h) // must not panic or corrupt an empty queue
+	if pq.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0", pq.Len())
+	}
+}
@@ -0,0 +1,119 @@
+package heap
+
+// Iter walks the queue's elements in heap order (not priority order)
+// without mutating it, calling yield for each element until yield returns
+// false or the elements are exhausted.
+func (pq *PriorityQueue[T]) Iter(yield func(T) bool) {
+	for _, item := range pq.data {
+		if !yield(item) {
+			return
+		}
+	}
+}
+
+// PopAll repeatedly pops the queue until empty and returns the results in
+// priority order. It runs in O(n log n) and leaves the queue empty.
+func (pq *PriorityQueue[T]) PopAll() []T {
+	out := make([]T, 0, len(pq.data))
+	for {
+		x, ok := pq.Pop()
+		if !ok {
+			break
+		}
+		out = append(out, x)
+	}
+	return out
+}
+
+// Sorted returns the queue's elements in priority order, leaving the
+// queue itself untouched. It runs in O(n log n) via an in-place heapsort
+// of a scratch copy of the backing slice.
+func (pq *PriorityQueue[T]) Sorted() []T {
+	data := append([]T(nil), pq.data...)
+	scratch := &PriorityQueue[T]{
+		data:       data,
+		comparator: pq.comparator,
+	}
+	for end := len(data) - 1; end > 0; end-- {
+		data[0], data[end] = data[end], data[0]
+		scratch.data = data[:end]
+		scratch.bubbleDown(0)
+	}
+	for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+		data[i], data[j] = data[j], data[i]
+	}
+	return data
+}
+
+// stableItem pairs a value with a monotonically increasing sequence
+// number so equal-priority items can be ordered by insertion order.
+type stableItem[T any] struct {
+	value T
+	seq   uint64
+}
+
+// StableQueue wraps a PriorityQueue so that items with equal priority pop
+// in FIFO insertion order instead of arbitrarily. See NewStable.
+type StableQueue[T any] struct {
+	pq  *PriorityQueue[stableItem[T]]
+	seq uint64
+}
+
+// NewStable creates a StableQueue with the given comparator. Ties (where
+// comparator returns 0) are broken by insertion order, so two equal
+// priority Tasks pop in the order they were pushed.
+func NewStable[T any](comparator Comparator[T]) *StableQueue[T] {
+	return &StableQueue[T]{
+		pq: New(func(a, b stableItem[T]) int {
+			if c := comparator(a.value, b.value); c != 0 {
+				return c
+			}
+			switch {
+			case a.seq < b.seq:
+				return -1
+			case a.seq > b.seq:
+				return 1
+			default:
+				return 0
+			}
+		}),
+	}
+}
+
+// Push inserts x into the queue, stamping it with the next sequence
+// number.
+func (sq *StableQueue[T]) Push(x T) {
+	sq.seq++
+	sq.pq.Push(stableItem[T]{value: x, seq: sq.seq})
+}
+
+// Pop removes and returns the highest priority element, breaking ties by
+// insertion order.
+func (sq *StableQueue[T]) Pop() (T, bool) {
+	item, ok := sq.pq.Pop()
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return item.value, true
+}
+
+// Peek returns the highest priority element without removing it.
+func (sq *StableQueue[T]) Peek() (T, bool) {
+	item, ok := sq.pq.Peek()
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return item.value, true
+}
+
+// Len returns the number of elements in the queue.
+func (sq *StableQueue[T]) Len() int {
+	return sq.pq.Len()
+}
+
+// IsEmpty returns true if the queue is empty.
+func (sq *StableQueue[T]) IsEmpty() bool {
+	return sq.pq.IsEmpty()
+}
@@ -0,0 +1,108 @@
+package heap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIterDoesNotMutateOrStop(t *testing.T) {
+	pq := From(func(a, b int) int { return a - b }, []int{5, 1, 9, 3, 7})
+	before := append([]int(nil), pq.data...)
+
+	var seen []int
+	pq.Iter(func(x int) bool {
+		seen = append(seen, x)
+		return true
+	})
+
+	if len(seen) != len(before) {
+		t.Fatalf("Iter visited %d elements, want %d", len(seen), len(before))
+	}
+	if !reflect.DeepEqual(pq.data, before) {
+		t.Fatalf("Iter mutated the queue: before %v, after %v", before, pq.data)
+	}
+	if pq.Len() != len(before) {
+		t.Fatalf("Len() = %d after Iter, want %d", pq.Len(), len(before))
+	}
+}
+
+func TestIterStopsEarlyWhenYieldReturnsFalse(t *testing.T) {
+	pq := From(func(a, b int) int { return a - b }, []int{5, 1, 9, 3, 7})
+
+	n := 0
+	pq.Iter(func(x int) bool {
+		n++
+		return n < 2
+	})
+
+	if n != 2 {
+		t.Fatalf("Iter called yield %d times, want 2 (stop right after yield returns false)", n)
+	}
+}
+
+func TestPopAllAndSortedAgree(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+	pq := From(cmp, []int{5, 1, 9, 3, 7})
+
+	sorted := pq.Sorted()
+	want := []int{1, 3, 5, 7, 9}
+	if !reflect.DeepEqual(sorted, want) {
+		t.Fatalf("Sorted() = %v, want %v", sorted, want)
+	}
+	if pq.Len() != 5 {
+		t.Fatalf("Sorted() should not mutate the queue, but Len() = %d, want 5", pq.Len())
+	}
+
+	popped := pq.PopAll()
+	if !reflect.DeepEqual(popped, want) {
+		t.Fatalf("PopAll() = %v, want %v", popped, want)
+	}
+	if pq.Len() != 0 {
+		t.Fatalf("Len() = %d after PopAll, want 0", pq.Len())
+	}
+}
+
+func TestStableQueueBreaksTiesFIFO(t *testing.T) {
+	type task struct {
+		priority int
+		name     string
+	}
+	sq := NewStable(func(a, b task) int { return a.priority - b.priority })
+
+	sq.Push(task{2, "first-p2"})
+	sq.Push(task{1, "only-p1"})
+	sq.Push(task{2, "second-p2"})
+	sq.Push(task{2, "third-p2"})
+
+	var order []string
+	for sq.Len() > 0 {
+		it, ok := sq.Pop()
+		if !ok {
+			t.Fatal("Pop() = false while Len() > 0")
+		}
+		order = append(order, it.name)
+	}
+
+	want := []string{"only-p1", "first-p2", "second-p2", "third-p2"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("pop order = %v, want %v (equal priorities broken by insertion order)", order, want)
+	}
+}
+
+func TestStableQueuePeekAndIsEmpty(t *testing.T) {
+	sq := NewStable(func(a, b int) int { return a - b })
+	if !sq.IsEmpty() {
+		t.Fatal("IsEmpty() = false on a new StableQueue")
+	}
+
+	sq.Push(3)
+	sq.Push(1)
+
+	v, ok := sq.Peek()
+	if !ok || v != 1 {
+		t.Fatalf("Peek() = (%d, %v), want (1, true)", v, ok)
+	}
+	if sq.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", sq.Len())
+	}
+}
@@ -0,0 +1,125 @@
+package heap
+
+// LazyQueue is a priority queue for workloads where an item's true
+// priority drifts over time (token-bucket schedulers, TTL caches,
+// peer-reputation queues). Rather than pay to keep every item's exact
+// priority honest on every tick, callers supply a cheap LowerBound used
+// for day-to-day ordering and an exact Priority used to validate it
+// lazily. New pushes land in the next heap (ordered by exact Priority);
+// Pop always drains the current heap, re-sifting an item using its exact
+// Priority as its new sort key instead of returning it when that key
+// materially disagrees with the LowerBound it was ordered by. Refresh
+// merges the two heaps back into one, recomputing every LowerBound, in
+// O(n).
+type LazyQueue[T any, P any] struct {
+	lowerBound func(T) P
+	priority   func(T) P
+	compare    Comparator[P]
+
+	current *IndexedPriorityQueue[lazyEntry[T, P]] // ordered by a snapshot key, drained by Pop
+	next    *IndexedPriorityQueue[T]                // ordered by Priority, filled by Push
+}
+
+// lazyEntry pairs a value with the key current is currently ordered by.
+// The key starts out as the value's LowerBound (set by Refresh) and is
+// overwritten with its exact Priority the first time Pop finds it stale,
+// so re-sifting actually changes where the entry sits in the heap.
+type lazyEntry[T any, P any] struct {
+	value T
+	key   P
+}
+
+// NewLazy creates a LazyQueue. lowerBound should be cheap to compute;
+// priority is the exact, possibly expensive, value it estimates. compare
+// orders P the same way a Comparator orders T: negative if a should pop
+// before b.
+func NewLazy[T any, P any](lowerBound, priority func(T) P, compare Comparator[P]) *LazyQueue[T, P] {
+	lq := &LazyQueue[T, P]{
+		lowerBound: lowerBound,
+		priority:   priority,
+		compare:    compare,
+	}
+	lq.current = NewIndexed(func(a, b lazyEntry[T, P]) int { return compare(a.key, b.key) })
+	lq.next = NewIndexed(func(a, b T) int { return compare(priority(a), priority(b)) })
+	return lq
+}
+
+// Push inserts x into the next heap, ordered by its exact Priority, and
+// returns a handle Update can later use to re-sift it in O(log n). The
+// handle is only valid until Refresh promotes x into current.
+func (lq *LazyQueue[T, P]) Push(x T) *Handle[T] {
+	return lq.next.Push(x)
+}
+
+// Update re-sifts h after the priority of the value it tracks has changed
+// externally. It only has an effect while h is still pending in next;
+// once Refresh has promoted it into current, Pop's own staleness check
+// takes over.
+func (lq *LazyQueue[T, P]) Update(h *Handle[T]) {
+	lq.next.Fix(h)
+}
+
+// Len returns the total number of elements across both internal heaps.
+func (lq *LazyQueue[T, P]) Len() int {
+	return lq.current.Len() + lq.next.Len()
+}
+
+// Pop removes and returns the highest priority element of the current
+// heap; elements still pending in next are not considered until current
+// is empty, at which point Pop first Refreshes from next. An entry whose key (a
+// LowerBound snapshot) materially disagrees with its exact Priority is
+// promoted to that Priority and re-sifted rather than returned.
+func (lq *LazyQueue[T, P]) Pop() (T, bool) {
+	if lq.current.Len() == 0 {
+		if lq.next.Len() == 0 {
+			var zero T
+			return zero, false
+		}
+		lq.Refresh()
+	}
+
+	for attempts := lq.current.Len(); attempts > 0; attempts-- {
+		top, ok := lq.current.Peek()
+		if !ok {
+			break
+		}
+		exact := lq.priority(top.value)
+		if lq.compare(exact, top.key) == 0 {
+			break
+		}
+		h := lq.current.data[0]
+		lq.current.Update(h, lazyEntry[T, P]{value: top.value, key: exact})
+	}
+
+	entry, ok := lq.current.Pop()
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// Refresh merges next into current, recomputing every entry's key from
+// LowerBound and re-heapifying in O(n). This is the safety net that keeps
+// decayed LowerBound estimates honest.
+func (lq *LazyQueue[T, P]) Refresh() {
+	entries := make([]*Handle[lazyEntry[T, P]], 0, len(lq.current.data)+len(lq.next.data))
+	for _, h := range lq.current.data {
+		h.value.key = lq.lowerBound(h.value.value)
+		entries = append(entries, h)
+	}
+	for _, h := range lq.next.data {
+		entries = append(entries, &Handle[lazyEntry[T, P]]{
+			value: lazyEntry[T, P]{value: h.value, key: lq.lowerBound(h.value)},
+		})
+	}
+	lq.next.data = lq.next.data[:0]
+
+	lq.current.data = entries
+	for i, h := range lq.current.data {
+		h.index = i
+	}
+	for i := len(lq.current.data)/2 - 1; i >= 0; i-- {
+		lq.current.bubbleDown(i)
+	}
+}
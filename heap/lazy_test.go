@@ -0,0 +1,79 @@
+package heap
+
+import "testing"
+
+// lazyItem carries an admissible (never-overestimating) LowerBound
+// alongside its exact, true priority. Some items are pushed with a bound
+// that's far too optimistic, forcing Pop through the staleness re-sift
+// path before the true winner can surface. This is the scenario that
+// 27f5135 got wrong: it detected the staleness but never actually moved
+// the entry, so Pop kept returning items in LowerBound order instead.
+type lazyItem struct {
+	id         int
+	truePrio   int
+	optimistic int
+}
+
+func TestLazyQueuePopCorrectsOptimisticBound(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+	lq := NewLazy(
+		func(it lazyItem) int { return it.optimistic },
+		func(it lazyItem) int { return it.truePrio },
+		cmp,
+	)
+
+	// item2's bound (5) makes it look best of all three, but its true
+	// priority (50) is actually the worst. item1's bound is exact.
+	items := []lazyItem{
+		{id: 1, truePrio: 10, optimistic: 10},
+		{id: 2, truePrio: 50, optimistic: 5},
+		{id: 3, truePrio: 30, optimistic: 25},
+	}
+	for _, it := range items {
+		lq.Push(it)
+	}
+
+	var got []int
+	for lq.Len() > 0 {
+		it, ok := lq.Pop()
+		if !ok {
+			t.Fatal("Pop() = false while Len() > 0")
+		}
+		got = append(got, it.id)
+	}
+
+	want := []int{1, 3, 2} // ascending true priority: 10, 30, 50
+	if len(got) != len(want) {
+		t.Fatalf("popped %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("popped order = %v, want %v (true priority order, not the optimistic bound order)", got, want)
+		}
+	}
+}
+
+func TestLazyQueueRefreshRecomputesBound(t *testing.T) {
+	cmp := func(a, b int) int { return a - b }
+	decay := map[int]int{1: 100, 2: 100, 3: 100}
+	lq := NewLazy(
+		func(id int) int { return decay[id] },
+		func(id int) int { return decay[id] },
+		cmp,
+	)
+
+	lq.Push(1)
+	lq.Push(2)
+	lq.Push(3)
+
+	// Force a Refresh, then change priorities behind the queue's back and
+	// Refresh again; the new ordering should reflect the updated values.
+	lq.Refresh()
+	decay[3] = 0
+
+	lq.Refresh()
+	got, ok := lq.Pop()
+	if !ok || got != 3 {
+		t.Fatalf("Pop() = (%v, %v), want (3, true) after Refresh picked up the lowered priority", got, ok)
+	}
+}